@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// SlaveChunkUploadUrl 生成从机分片上传地址，对应从机端的
+// PUT /slave/upload/{sessionID}/{chunkIndex}。
+func SlaveChunkUploadUrl(server *url.URL, sessionID string, chunkIndex int64) *url.URL {
+	u := *server
+	u.Path = fmt.Sprintf("/slave/upload/%s/%d", sessionID, chunkIndex)
+	return &u
+}
+
+// SlaveCompleteChunkUploadUrl 生成从机分片拼装地址，对应从机端的
+// POST /slave/upload/{sessionID}/complete，通知从机把已上传的全部分片
+// 按序拼装为最终文件并清理本地暂存的分片。
+func SlaveCompleteChunkUploadUrl(server *url.URL, sessionID string) *url.URL {
+	u := *server
+	u.Path = fmt.Sprintf("/slave/upload/%s/complete", sessionID)
+	return &u
+}
@@ -0,0 +1,11 @@
+package routes
+
+import "net/url"
+
+// SlaveStatusUrl 生成从机健康状况探测地址，对应从机端的 GET /slave/status，
+// 返回该节点的可用/已用空间、CPU 负载与在途上传数。
+func SlaveStatusUrl(server *url.URL) *url.URL {
+	u := *server
+	u.Path = "/slave/status"
+	return &u
+}
@@ -0,0 +1,87 @@
+package slave
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// chunkUploadPathPrefix 对应 routes.SlaveChunkUploadUrl 生成的地址前缀。
+const chunkUploadPathPrefix = "/slave/upload/"
+
+// chunkCompleteSuffix 是 routes.SlaveCompleteChunkUploadUrl 地址的结尾部分。
+const chunkCompleteSuffix = "/complete"
+
+// HandleChunkUpload 处理 PUT /slave/upload/{sessionID}/{chunkIndex}，把
+// 请求体保存为该会话的一个分片。鉴权（HMAC 签名校验）由外层路由中间件
+// 完成，这里只负责分片的落盘。
+func (m *ChunkSessionManager) HandleChunkUpload(w http.ResponseWriter, r *http.Request) {
+	sessionID, chunkIndex, err := parseChunkUploadPath(r.URL.Path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	defer r.Body.Close()
+	if err := m.SaveChunk(sessionID, chunkIndex, r.Body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// HandleChunkComplete 处理 POST /slave/upload/{sessionID}/complete，触发该
+// 会话已上传分片的拼装。鉴权（HMAC 签名校验）由外层路由中间件完成。
+func (m *ChunkSessionManager) HandleChunkComplete(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := parseChunkCompletePath(r.URL.Path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := m.Complete(sessionID); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// parseChunkUploadPath 从请求路径中解析出 sessionID 与 chunkIndex，路径格式
+// 需要与 routes.SlaveChunkUploadUrl 保持一致。
+func parseChunkUploadPath(path string) (string, int64, error) {
+	rest := strings.TrimPrefix(path, chunkUploadPathPrefix)
+	if rest == path {
+		return "", 0, fmt.Errorf("slave: path %q does not match chunk upload route", path)
+	}
+
+	parts := strings.Split(rest, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", 0, fmt.Errorf("slave: malformed chunk upload path %q", path)
+	}
+
+	chunkIndex, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || chunkIndex < 0 {
+		return "", 0, fmt.Errorf("slave: invalid chunk index %q", parts[1])
+	}
+
+	return parts[0], chunkIndex, nil
+}
+
+// parseChunkCompletePath 从请求路径中解析出 sessionID，路径格式需要与
+// routes.SlaveCompleteChunkUploadUrl 保持一致。
+func parseChunkCompletePath(path string) (string, error) {
+	rest := strings.TrimPrefix(path, chunkUploadPathPrefix)
+	if rest == path || !strings.HasSuffix(rest, chunkCompleteSuffix) {
+		return "", fmt.Errorf("slave: path %q does not match chunk complete route", path)
+	}
+
+	sessionID := strings.TrimSuffix(rest, chunkCompleteSuffix)
+	if sessionID == "" {
+		return "", fmt.Errorf("slave: malformed chunk complete path %q", path)
+	}
+
+	return sessionID, nil
+}
@@ -0,0 +1,241 @@
+// Package slave 实现从机侧接收分片上传请求的逻辑：分片落盘、在收到
+// 完成通知后按序拼装为最终文件，以及清理长期未完成的分片会话。
+// 对应的地址由 pkg/cluster/routes 中的 SlaveChunkUploadUrl /
+// SlaveCompleteChunkUploadUrl 生成，主机侧发起方见
+// pkg/filemanager/driver/remote。
+package slave
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// chunkSessionIdleTTL 分片会话允许的最长空闲时间，超过后在下一次 GC 扫描
+// 中被视为废弃会话并清理，避免客户端中途放弃上传后分片永久占用磁盘。
+const chunkSessionIdleTTL = 24 * time.Hour
+
+// ChunkSessionManager 管理分片上传会话在从机本地磁盘上的落盘、拼装与回收。
+// 每个上传会话对应 baseDir 下的一个同名子目录，目录内以分片序号命名分片
+// 文件。会话状态只以磁盘上是否存在对应目录/分片文件为准，不在内存中
+// 另外记录——从机进程重启后分片文件仍在磁盘上，GC 与 Complete 都得看到
+// 重启前已经写入的分片，而不是因为内存状态丢失就放任分片永久占用磁盘，
+// 或者把已经传完的会话误判成"从未分片上传过"而跳过拼装。
+type ChunkSessionManager struct {
+	baseDir     string
+	resolveDest func(sessionID string) (string, error)
+}
+
+// NewChunkSessionManager 创建一个以 baseDir 为分片暂存根目录的会话管理器，
+// 由从机启动流程持有并复用，不随单次请求创建/销毁。resolveDest 把
+// sessionID 解析为该上传会话在从机本地的最终落盘路径，复用从机已有的
+// 上传会话记录（与非分片上传共享同一份会话元数据）。
+func NewChunkSessionManager(baseDir string, resolveDest func(sessionID string) (string, error)) *ChunkSessionManager {
+	return &ChunkSessionManager{
+		baseDir:     baseDir,
+		resolveDest: resolveDest,
+	}
+}
+
+func (m *ChunkSessionManager) sessionDir(sessionID string) string {
+	return filepath.Join(m.baseDir, sessionID)
+}
+
+// SaveChunk 把 sessionID 的第 chunkIndex 个分片写入本地暂存目录。
+func (m *ChunkSessionManager) SaveChunk(sessionID string, chunkIndex int64, r io.Reader) error {
+	if sessionID == "" {
+		return fmt.Errorf("slave: empty chunk session id")
+	}
+	if chunkIndex < 0 {
+		return fmt.Errorf("slave: invalid chunk index %d", chunkIndex)
+	}
+
+	dir := m.sessionDir(sessionID)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("slave: failed to create chunk session dir: %w", err)
+	}
+
+	dst, err := os.Create(filepath.Join(dir, strconv.FormatInt(chunkIndex, 10)))
+	if err != nil {
+		return fmt.Errorf("slave: failed to create chunk file: %w", err)
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, r); err != nil {
+		return fmt.Errorf("slave: failed to write chunk %d: %w", chunkIndex, err)
+	}
+
+	return nil
+}
+
+// AssembleAndCleanup 把 sessionID 已落盘的分片按序号拼装写入 dst。没有任何
+// 分片落过盘的会话（文件大小未超过单个分片，从未走分片上传路径）是
+// no-op。无论成功与否，返回前都会清理该会话的暂存目录，避免 CompleteUpload
+// 重试时残留半成品分片。
+func (m *ChunkSessionManager) AssembleAndCleanup(sessionID string, dst io.Writer) error {
+	dir := m.sessionDir(sessionID)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("slave: failed to list chunk session dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	indexes := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		idx, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil {
+			continue
+		}
+		indexes = append(indexes, idx)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+	for _, idx := range indexes {
+		if err := appendChunk(dir, idx, dst); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func appendChunk(dir string, idx int64, dst io.Writer) error {
+	src, err := os.Open(filepath.Join(dir, strconv.FormatInt(idx, 10)))
+	if err != nil {
+		return fmt.Errorf("slave: failed to open chunk %d: %w", idx, err)
+	}
+	defer src.Close()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return fmt.Errorf("slave: failed to append chunk %d: %w", idx, err)
+	}
+
+	return nil
+}
+
+// Complete 把 sessionID 已落盘的分片拼装进它的最终目标文件。没有分片落过
+// 盘的会话（未走分片上传路径）是 no-op，交由从机既有的单文件上传流程处理。
+func (m *ChunkSessionManager) Complete(sessionID string) error {
+	dest, err := m.resolveDest(sessionID)
+	if err != nil {
+		return fmt.Errorf("slave: failed to resolve destination for session %q: %w", sessionID, err)
+	}
+
+	if !m.hasChunks(sessionID) {
+		return nil
+	}
+
+	dst, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("slave: failed to create destination file: %w", err)
+	}
+	defer dst.Close()
+
+	return m.AssembleAndCleanup(sessionID, dst)
+}
+
+// hasChunks 直接查磁盘判断该会话是否落过分片，而不依赖进程内存——从机
+// 重启后内存状态会丢失，但分片文件还在磁盘上，必须仍然能被正确拼装。
+func (m *ChunkSessionManager) hasChunks(sessionID string) bool {
+	entries, err := os.ReadDir(m.sessionDir(sessionID))
+	return err == nil && len(entries) > 0
+}
+
+// Sweep 扫描 baseDir 下的全部会话目录，清理最后一次写入时间超过
+// chunkSessionIdleTTL 的废弃会话，返回被清理的会话数。直接以磁盘上各
+// 分片文件的 mtime 为准，因此跨进程重启仍然可靠。
+func (m *ChunkSessionManager) Sweep(now time.Time) int {
+	entries, err := os.ReadDir(m.baseDir)
+	if err != nil {
+		return 0
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		sessionID := entry.Name()
+		lastActive, err := m.lastActivity(sessionID)
+		if err != nil {
+			continue
+		}
+
+		if now.Sub(lastActive) > chunkSessionIdleTTL {
+			if err := os.RemoveAll(m.sessionDir(sessionID)); err == nil {
+				removed++
+			}
+		}
+	}
+
+	return removed
+}
+
+// lastActivity 返回 sessionID 下最近一次被写入的分片文件的 mtime，作为该
+// 会话的最后活动时间。
+func (m *ChunkSessionManager) lastActivity(sessionID string) (time.Time, error) {
+	entries, err := os.ReadDir(m.sessionDir(sessionID))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var latest time.Time
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	if latest.IsZero() {
+		return latest, fmt.Errorf("slave: session %q has no chunk files", sessionID)
+	}
+
+	return latest, nil
+}
+
+// ActiveSessionCount 返回当前磁盘上暂存着分片、尚未完成的会话数，供
+// Stats 上报 InflightUploads 使用。
+func (m *ChunkSessionManager) ActiveSessionCount() int {
+	entries, err := os.ReadDir(m.baseDir)
+	if err != nil {
+		return 0
+	}
+
+	count := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Run 周期性地调用 Sweep 清理废弃会话，随 stop 被关闭退出。
+// ChunkSessionManager 按从机进程的生命周期创建一次，这里的后台协程不会
+// 像按请求/按策略创建的对象那样无人负责关闭。
+func (m *ChunkSessionManager) Run(stop <-chan struct{}, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			m.Sweep(now)
+		}
+	}
+}
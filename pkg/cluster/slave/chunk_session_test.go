@@ -0,0 +1,143 @@
+package slave
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChunkSessionManagerSaveAndAssembleInOrder(t *testing.T) {
+	dir := t.TempDir()
+	destDir := t.TempDir()
+	dest := filepath.Join(destDir, "final.bin")
+
+	m := NewChunkSessionManager(dir, func(sessionID string) (string, error) {
+		return dest, nil
+	})
+
+	const sessionID = "session-1"
+	// 故意乱序写入，拼装结果必须按分片序号排序，不按写入顺序
+	if err := m.SaveChunk(sessionID, 1, strings.NewReader("world")); err != nil {
+		t.Fatalf("SaveChunk(1) failed: %v", err)
+	}
+	if err := m.SaveChunk(sessionID, 0, strings.NewReader("hello ")); err != nil {
+		t.Fatalf("SaveChunk(0) failed: %v", err)
+	}
+
+	if err := m.Complete(sessionID); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	got, err := readFile(dest)
+	if err != nil {
+		t.Fatalf("failed to read assembled file: %v", err)
+	}
+	if got != "hello world" {
+		t.Fatalf("assembled content = %q, want %q", got, "hello world")
+	}
+
+	// 会话目录应已被清理
+	if _, err := readFile(filepath.Join(dir, sessionID, "0")); err == nil {
+		t.Fatal("expected chunk session directory to be removed after Complete")
+	}
+}
+
+func TestChunkSessionManagerCompleteWithoutChunksIsNoop(t *testing.T) {
+	dir := t.TempDir()
+	destDir := t.TempDir()
+	dest := filepath.Join(destDir, "final.bin")
+
+	m := NewChunkSessionManager(dir, func(sessionID string) (string, error) {
+		return dest, nil
+	})
+
+	// 从未调用过 SaveChunk：文件没有超过单个分片，走的是普通上传路径
+	if err := m.Complete("never-chunked"); err != nil {
+		t.Fatalf("Complete on a session with no chunks should be a no-op, got error: %v", err)
+	}
+
+	if _, err := readFile(dest); err == nil {
+		t.Fatal("expected no destination file to be created for a session with no chunks")
+	}
+}
+
+func TestChunkSessionManagerCompleteSurvivesProcessRestart(t *testing.T) {
+	dir := t.TempDir()
+	destDir := t.TempDir()
+	dest := filepath.Join(destDir, "final.bin")
+
+	resolveDest := func(sessionID string) (string, error) { return dest, nil }
+	m := NewChunkSessionManager(dir, resolveDest)
+
+	const sessionID = "session-restart"
+	if err := m.SaveChunk(sessionID, 0, strings.NewReader("hello ")); err != nil {
+		t.Fatalf("SaveChunk(0) failed: %v", err)
+	}
+	if err := m.SaveChunk(sessionID, 1, strings.NewReader("world")); err != nil {
+		t.Fatalf("SaveChunk(1) failed: %v", err)
+	}
+
+	// 模拟从机进程重启：用同一个 baseDir 重新构造一个全新的
+	// ChunkSessionManager，不带任何前一个实例的进程内状态。Complete 必须
+	// 仍然能看到磁盘上已经落盘的分片并完成拼装。
+	restarted := NewChunkSessionManager(dir, resolveDest)
+	if err := restarted.Complete(sessionID); err != nil {
+		t.Fatalf("Complete after restart failed: %v", err)
+	}
+
+	got, err := readFile(dest)
+	if err != nil {
+		t.Fatalf("failed to read assembled file: %v", err)
+	}
+	if got != "hello world" {
+		t.Fatalf("assembled content = %q, want %q", got, "hello world")
+	}
+}
+
+func TestChunkSessionManagerSweepRemovesOnlyStaleSessions(t *testing.T) {
+	dir := t.TempDir()
+	m := NewChunkSessionManager(dir, func(sessionID string) (string, error) { return "", nil })
+
+	if err := m.SaveChunk("stale", 0, strings.NewReader("x")); err != nil {
+		t.Fatalf("SaveChunk failed: %v", err)
+	}
+	if err := m.SaveChunk("fresh", 0, strings.NewReader("x")); err != nil {
+		t.Fatalf("SaveChunk failed: %v", err)
+	}
+
+	// 模拟该会话的分片文件是在 TTL 之前写入的——Sweep 只看磁盘上的 mtime，
+	// 这样即使从机进程在这之间重启过，废弃会话依然能被正确识别并清理。
+	staleChunk := filepath.Join(dir, "stale", "0")
+	staleTime := time.Now().Add(-chunkSessionIdleTTL - time.Minute)
+	if err := os.Chtimes(staleChunk, staleTime, staleTime); err != nil {
+		t.Fatalf("os.Chtimes failed: %v", err)
+	}
+
+	removed := m.Sweep(time.Now())
+	if removed != 1 {
+		t.Fatalf("expected Sweep to remove exactly 1 stale session, removed %d", removed)
+	}
+
+	if _, err := readFile(filepath.Join(dir, "fresh", "0")); err != nil {
+		t.Fatalf("expected fresh session chunk to survive sweep: %v", err)
+	}
+	if _, err := readFile(filepath.Join(dir, "stale", "0")); err == nil {
+		t.Fatal("expected stale session chunk to be removed by sweep")
+	}
+}
+
+func readFile(path string) (string, error) {
+	var buf bytes.Buffer
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := buf.ReadFrom(f); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
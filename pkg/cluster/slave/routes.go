@@ -0,0 +1,25 @@
+package slave
+
+import (
+	"net/http"
+	"strings"
+)
+
+// RegisterChunkRoutes 把分片上传/拼装接口挂载到 mux 上，对应
+// pkg/cluster/routes 里 SlaveChunkUploadUrl/SlaveCompleteChunkUploadUrl
+// 生成的地址。鉴权中间件由调用方自行在 mux 外层包裹，这里只负责路由本身。
+func RegisterChunkRoutes(mux *http.ServeMux, chunks *ChunkSessionManager) {
+	mux.HandleFunc(chunkUploadPathPrefix, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, chunkCompleteSuffix) {
+			chunks.HandleChunkComplete(w, r)
+			return
+		}
+
+		if r.Method == http.MethodPut {
+			chunks.HandleChunkUpload(w, r)
+			return
+		}
+
+		http.Error(w, "slave: unsupported method or path", http.StatusMethodNotAllowed)
+	})
+}
@@ -0,0 +1,63 @@
+package slave
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestRegisterChunkRoutesUploadAndComplete 通过一个真实的 *http.ServeMux +
+// httptest.Server 驱动完整的分片上传/拼装流程，证明 HandleChunkUpload/
+// HandleChunkComplete 确实挂在了路由上、能被真实的 HTTP 请求触达，而不是
+// 只在单测里被直接调用。
+func TestRegisterChunkRoutesUploadAndComplete(t *testing.T) {
+	dir := t.TempDir()
+	destDir := t.TempDir()
+	dest := filepath.Join(destDir, "final.bin")
+
+	chunks := NewChunkSessionManager(dir, func(sessionID string) (string, error) { return dest, nil })
+
+	mux := http.NewServeMux()
+	RegisterChunkRoutes(mux, chunks)
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	const sessionID = "http-session"
+
+	for idx, body := range []string{"hello ", "world"} {
+		req, err := http.NewRequest(http.MethodPut, srv.URL+"/slave/upload/"+sessionID+"/"+strconv.Itoa(idx), strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("failed to build chunk upload request: %v", err)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("chunk upload request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("chunk upload returned status %d", resp.StatusCode)
+		}
+	}
+
+	resp, err := http.Post(srv.URL+"/slave/upload/"+sessionID+"/complete", "", nil)
+	if err != nil {
+		t.Fatalf("chunk complete request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("chunk complete returned status %d", resp.StatusCode)
+	}
+
+	got, err := readFile(dest)
+	if err != nil {
+		t.Fatalf("failed to read assembled file: %v", err)
+	}
+	if got != "hello world" {
+		t.Fatalf("assembled content = %q, want %q", got, "hello world")
+	}
+}
@@ -0,0 +1,72 @@
+package slave
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// NodeStatusReport 是 GET /slave/status 的响应体，描述本机当前的容量与
+// 负载状况，每个字段对应 pkg/filemanager/driver/remote.NodeStats 中的同名
+// 字段，由主机侧探测后直接搬运进去；NodeStats 的 Latency、Offline、ProbedAt
+// 不在响应体中，由主机侧自行填写。
+type NodeStatusReport struct {
+	FreeBytes       uint64  `json:"free_bytes"`
+	UsedBytes       uint64  `json:"used_bytes"`
+	CPUUsage        float64 `json:"cpu_usage"`
+	InflightUploads int     `json:"inflight_uploads"`
+}
+
+// StatusHandler 汇总本机的磁盘容量、CPU 负载与在途分片上传数，响应主机侧
+// 的健康探测请求。dataDir 是分片/文件实际落盘的根目录，用它所在的文件系统
+// 容量作为该节点的可用/已用空间；chunks 是同一个从机进程持有的
+// ChunkSessionManager，复用它统计当前在途的分片上传会话数。
+type StatusHandler struct {
+	dataDir string
+	chunks  *ChunkSessionManager
+}
+
+// NewStatusHandler 创建一个汇报 dataDir 所在文件系统容量与 chunks 管理的
+// 在途分片会话数的健康探测处理器。
+func NewStatusHandler(dataDir string, chunks *ChunkSessionManager) *StatusHandler {
+	return &StatusHandler{dataDir: dataDir, chunks: chunks}
+}
+
+// HandleStatus 处理 GET /slave/status，返回本机当前的健康状况。鉴权
+// （HMAC 签名校验）由外层路由中间件完成，这里只负责采集与汇报。
+func (h *StatusHandler) HandleStatus(w http.ResponseWriter, r *http.Request) {
+	free, used, err := diskUsage(h.dataDir)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	load, err := cpuUsage()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	report := NodeStatusReport{
+		FreeBytes:       free,
+		UsedBytes:       used,
+		CPUUsage:        load,
+		InflightUploads: h.chunks.ActiveSessionCount(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// RegisterStatusRoute 把健康探测接口挂载到 mux 上，对应
+// pkg/cluster/routes.SlaveStatusUrl 生成的地址。鉴权中间件由调用方自行在
+// mux 外层包裹，这里只负责路由本身。
+func RegisterStatusRoute(mux *http.ServeMux, status *StatusHandler) {
+	mux.HandleFunc("/slave/status", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "slave: unsupported method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		status.HandleStatus(w, r)
+	})
+}
@@ -0,0 +1,53 @@
+//go:build linux
+
+package slave
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// diskUsage 返回 path 所在文件系统的可用/已用字节数。
+func diskUsage(path string) (free, used uint64, err error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, fmt.Errorf("slave: failed to stat filesystem for %q: %w", path, err)
+	}
+
+	free = stat.Bavail * uint64(stat.Bsize)
+	total := stat.Blocks * uint64(stat.Bsize)
+	used = total - stat.Bfree*uint64(stat.Bsize)
+
+	return free, used, nil
+}
+
+// cpuUsage 返回最近 1 分钟的系统平均负载（/proc/loadavg 的第一列），作为
+// 节点 CPU 繁忙程度的近似指标。
+func cpuUsage() (float64, error) {
+	f, err := os.Open("/proc/loadavg")
+	if err != nil {
+		return 0, fmt.Errorf("slave: failed to read /proc/loadavg: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		return 0, fmt.Errorf("slave: /proc/loadavg is empty")
+	}
+
+	fields := strings.Fields(scanner.Text())
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("slave: malformed /proc/loadavg content")
+	}
+
+	load, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("slave: failed to parse load average: %w", err)
+	}
+
+	return load, nil
+}
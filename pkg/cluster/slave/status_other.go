@@ -0,0 +1,16 @@
+//go:build !linux
+
+package slave
+
+import "fmt"
+
+// diskUsage 在非 linux 平台上没有实现，直接报错而不是汇报一个虚假的值，
+// 调用方（HandleStatus）据此把本次探测当成失败处理。
+func diskUsage(path string) (free, used uint64, err error) {
+	return 0, 0, fmt.Errorf("slave: disk usage probing is not implemented on this platform")
+}
+
+// cpuUsage 在非 linux 平台上没有实现，原因同 diskUsage。
+func cpuUsage() (float64, error) {
+	return 0, fmt.Errorf("slave: cpu load probing is not implemented on this platform")
+}
@@ -0,0 +1,55 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/cloudreve/Cloudreve/v4/pkg/auth"
+	"github.com/cloudreve/Cloudreve/v4/pkg/cluster/routes"
+	"github.com/cloudreve/Cloudreve/v4/pkg/filemanager/fs"
+)
+
+// chunkUploadURLs 按照策略的 ChunkSize 为上传会话预签一组分片上传地址，
+// 每个地址携带独立的 HMAC 签名与分片序号，客户端可并行上传分片，
+// 也可以仅重试其中失败的分片而无需重建整个上传会话。当文件大小不超过
+// 单个分片时，退化为签发单个上传地址，行为与此前保持一致。
+//
+// 每个地址对应从机端的 PUT /slave/upload/{sessionID}/{chunkIndex}，
+// 由从机的 pkg/cluster/slave.ChunkSessionManager 负责落盘分片；收到全部
+// 分片后的拼装由 Driver.CompleteUpload 触发（见 remote.go），长期未完成
+// 的会话由 ChunkSessionManager.Run 定期清理。
+func (handler *Driver) chunkUploadURLs(ctx context.Context, uploadSession *fs.UploadSession, file *fs.UploadRequest, chunkSize int64) ([]string, string, error) {
+	uploadURL, sign, err := handler.uploadClient.GetUploadURL(ctx, uploadSession.Props.ExpireAt, uploadSession.Props.UploadSessionID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to sign upload url: %w", err)
+	}
+
+	if chunkSize <= 0 || file.Props.Size <= chunkSize {
+		return []string{uploadURL}, sign, nil
+	}
+
+	server, err := url.Parse(handler.Policy.Edges.Node.Server)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse node server url: %w", err)
+	}
+
+	chunks := chunkCount(file.Props.Size, chunkSize)
+	uploadURLs := make([]string, chunks)
+	for i := int64(0); i < chunks; i++ {
+		chunkURL := routes.SlaveChunkUploadUrl(server, uploadSession.Props.UploadSessionID, i)
+		signed, err := auth.SignURI(ctx, handler.AuthInstance, chunkURL.String(), uploadSession.Props.ExpireAt)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to sign upload url for chunk %d: %w", i, err)
+		}
+
+		uploadURLs[i] = signed.String()
+	}
+
+	return uploadURLs, sign, nil
+}
+
+// chunkCount 计算文件按 chunkSize 切分后的分片数量，向上取整。
+func chunkCount(fileSize, chunkSize int64) int64 {
+	return (fileSize + chunkSize - 1) / chunkSize
+}
@@ -0,0 +1,26 @@
+package remote
+
+import "testing"
+
+func TestChunkCount(t *testing.T) {
+	cases := []struct {
+		fileSize  int64
+		chunkSize int64
+		want      int64
+	}{
+		{0, 10, 0},
+		{1, 10, 1},
+		{10, 10, 1},
+		{11, 10, 2},
+		{20, 10, 2},
+		{21, 10, 3},
+		{1 << 30, 1 << 20, 1 << 10},
+	}
+
+	for _, c := range cases {
+		got := chunkCount(c.fileSize, c.chunkSize)
+		if got != c.want {
+			t.Errorf("chunkCount(%d, %d) = %d, want %d", c.fileSize, c.chunkSize, got, c.want)
+		}
+	}
+}
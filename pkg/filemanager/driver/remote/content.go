@@ -0,0 +1,304 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudreve/Cloudreve/v4/pkg/auth"
+	"github.com/cloudreve/Cloudreve/v4/pkg/cluster/routes"
+	"github.com/cloudreve/Cloudreve/v4/pkg/conf"
+	"github.com/cloudreve/Cloudreve/v4/pkg/request"
+)
+
+const (
+	// contentURLExpire 内容地址签名的有效期，避免签出一个长期可重放的 URL
+	contentURLExpire = 15 * time.Minute
+	// contentProbeTimeout 仅用于探测文件总大小的小请求（Range: bytes=0-0）
+	// 的超时时间，不会套用到实际的内容流读取上——慢链路上的大文件可能
+	// 需要远超这个时间才能读完，不应该被一刀切掉
+	contentProbeTimeout = 10 * time.Second
+	// contentStreamRetryMax 单次 Read 链路上，连接中断后允许的最大重试次数
+	contentStreamRetryMax = 3
+	// contentStreamRetryBackoff 相邻两次重试之间等待时间的基数
+	contentStreamRetryBackoff = 500 * time.Millisecond
+)
+
+// remoteContentURL 生成带签名且限时有效的从机文件内容地址。
+func (handler *Driver) remoteContentURL(ctx context.Context, path string) (string, error) {
+	server, err := url.Parse(handler.Policy.Edges.Node.Server)
+	if err != nil {
+		return "", fmt.Errorf("remote: failed to parse node server url: %w", err)
+	}
+
+	nodeId := 0
+	if handler.config.System().Mode == conf.SlaveMode {
+		nodeId = handler.Policy.NodeID
+	}
+
+	base := routes.SlaveFileContentUrl(server, path, "", false, 0, nodeId)
+	expire := time.Now().Add(contentURLExpire)
+	signed, err := auth.SignURI(ctx, handler.AuthInstance, base.String(), &expire)
+	if err != nil {
+		return "", fmt.Errorf("remote: failed to sign content url: %w", err)
+	}
+
+	return signed.String(), nil
+}
+
+// remoteFileStream 需要满足 io.ReadSeekCloser，Open 以这个接口类型返回它。
+var _ io.ReadSeekCloser = (*remoteFileStream)(nil)
+
+// Open 返回一个按需从从机拉取内容的 io.ReadSeekCloser：数据在 Read 被调用
+// 时才通过签名 Range 请求从从机读取，不会在返回前整体预取到本地磁盘或
+// 内存，所以慢链路上的大文件也能立刻开始读取，不必等待传输完成。
+//
+// 本次改动只涉及 remote 包：这份检出里不包含 driver.Handler 接口定义及
+// 其他策略的实现，无法在这里同步校验/更新 Handler.Open 的签名或其他跳过
+// 远程策略的调用方是否兼容。接入完整代码库后，需要确认 driver.Handler 与
+// 其余实现（本地/从机等策略）都已经改为同样的 io.ReadSeekCloser 签名。
+func (handler *Driver) Open(ctx context.Context, path string) (io.ReadSeekCloser, error) {
+	contentURL, err := handler.remoteContentURL(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remoteFileStream{
+		ctx:  ctx,
+		size: -1,
+		openRange: func(c context.Context, offset int64) (io.ReadCloser, int64, error) {
+			return handler.openContentRange(c, contentURL, offset)
+		},
+		probeSizeFn: func(c context.Context) (int64, error) { return handler.probeContentSize(c, contentURL) },
+	}, nil
+}
+
+// rangeOpenFunc 对远端内容发起一次从 offset 开始的 Range 请求，返回可持续
+// 读取的响应体，以及从响应头解出的文件总大小（未知时为 -1）。抽成函数
+// 类型而非直接依赖 *Driver，便于单测在不搭建真实 HTTP 客户端的情况下
+// 注入可控的响应序列来验证 remoteFileStream 的重试/续传/跳转逻辑。
+type rangeOpenFunc func(ctx context.Context, offset int64) (io.ReadCloser, int64, error)
+
+// remoteFileStream 是从机文件内容的流式读取器，实现 io.ReadSeekCloser。
+// 底层通过 Driver.Client（由全局配置创建的通用 HTTP 客户端）发起
+// Range 请求，读到哪里才请求到哪里；连接中断时从当前已读到的偏移量续传，
+// 而不是重新下载整个文件。
+type remoteFileStream struct {
+	ctx         context.Context
+	openRange   rangeOpenFunc
+	probeSizeFn func(ctx context.Context) (int64, error)
+
+	pos    int64
+	size   int64 // 文件总大小，探测前为 -1
+	body   io.ReadCloser
+	closed bool
+}
+
+// Read 实现 io.Reader。底层连接尚未建立或上一次读取中断时，会在当前偏移量
+// 重新发起 Range 请求续传，最多重试 contentStreamRetryMax 次。
+func (s *remoteFileStream) Read(p []byte) (int, error) {
+	if s.closed {
+		return 0, fmt.Errorf("remote: read from closed file stream")
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= contentStreamRetryMax; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-s.ctx.Done():
+				return 0, s.ctx.Err()
+			case <-time.After(contentStreamRetryBackoff * time.Duration(attempt)):
+			}
+		}
+
+		if s.body == nil {
+			body, size, err := s.openRange(s.ctx, s.pos)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			if size >= 0 {
+				s.size = size
+			}
+			s.body = body
+		}
+
+		n, err := s.body.Read(p)
+		s.pos += int64(n)
+
+		if err == nil {
+			return n, nil
+		}
+
+		s.body.Close()
+		s.body = nil
+
+		if err == io.EOF {
+			return n, io.EOF
+		}
+
+		lastErr = err
+		if n > 0 {
+			// 已有数据返回给调用方，把中断处理推迟到下一次 Read 再重试，
+			// 避免丢弃已经读到的数据
+			return n, nil
+		}
+	}
+
+	return 0, fmt.Errorf("remote: failed to read file content after %d attempts: %w", contentStreamRetryMax+1, lastErr)
+}
+
+// Seek 实现 io.Seeker。向前/向后跳转都只是调整偏移量，真正的网络请求推迟到
+// 下一次 Read 时按新偏移量发起，代价是跳转本身几乎零开销。
+func (s *remoteFileStream) Seek(offset int64, whence int) (int64, error) {
+	if s.closed {
+		return 0, fmt.Errorf("remote: seek on closed file stream")
+	}
+
+	var newPos int64
+	switch whence {
+	case io.SeekStart:
+		newPos = offset
+	case io.SeekCurrent:
+		newPos = s.pos + offset
+	case io.SeekEnd:
+		size, err := s.probeSize()
+		if err != nil {
+			return 0, err
+		}
+		newPos = size + offset
+	default:
+		return 0, fmt.Errorf("remote: invalid seek whence %d", whence)
+	}
+
+	if newPos < 0 {
+		return 0, fmt.Errorf("remote: negative seek position %d", newPos)
+	}
+
+	if newPos != s.pos && s.body != nil {
+		s.body.Close()
+		s.body = nil
+	}
+
+	s.pos = newPos
+	return s.pos, nil
+}
+
+// Close 实现 io.Closer。
+func (s *remoteFileStream) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	if s.body != nil {
+		err := s.body.Close()
+		s.body = nil
+		return err
+	}
+
+	return nil
+}
+
+// openContentRange 对 contentURL 发起从 offset 开始的 Range 请求，返回可
+// 持续读取的响应体及响应头中携带的文件总大小（未知时为 -1）。调用方负责
+// 在用完或出错后关闭返回的 io.ReadCloser。不对这次调用本身附加额外的超
+// 时：读多久由调用方传入的 ctx 决定，避免慢链路上的大文件在还没读完时
+// 就被一刀切断。
+func (handler *Driver) openContentRange(ctx context.Context, contentURL string, offset int64) (io.ReadCloser, int64, error) {
+	opts := []request.Option{request.WithContext(ctx)}
+	if offset > 0 {
+		opts = append(opts, request.WithHeader(http.Header{"Range": {fmt.Sprintf("bytes=%d-", offset)}}))
+	}
+
+	resp := handler.Client.Request(http.MethodGet, contentURL, nil, opts...)
+	if resp.Err != nil {
+		return nil, -1, fmt.Errorf("remote: range request failed: %w", resp.Err)
+	}
+
+	status := resp.Response.StatusCode
+	switch {
+	case offset > 0 && status != http.StatusPartialContent:
+		resp.Response.Body.Close()
+		return nil, -1, fmt.Errorf("remote: slave node did not honor range request (got status %d)", status)
+	case offset == 0 && status != http.StatusOK && status != http.StatusPartialContent:
+		resp.Response.Body.Close()
+		return nil, -1, fmt.Errorf("remote: unexpected status code %d while fetching content", status)
+	}
+
+	size := int64(-1)
+	if parsed, ok := parseContentRangeSize(resp.Response.Header.Get("Content-Range")); ok {
+		size = parsed
+	} else if cl := resp.Response.Header.Get("Content-Length"); offset == 0 && cl != "" {
+		if parsed, err := strconv.ParseInt(cl, 10, 64); err == nil {
+			size = parsed
+		}
+	}
+
+	return resp.Response.Body, size, nil
+}
+
+// probeSize 在总大小尚未知晓时，探测一次文件总大小，不影响当前的流式
+// 读取位置；结果会被缓存，后续 Seek(io.SeekEnd) 不会重复探测。
+func (s *remoteFileStream) probeSize() (int64, error) {
+	if s.size >= 0 {
+		return s.size, nil
+	}
+
+	size, err := s.probeSizeFn(s.ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	s.size = size
+	return s.size, nil
+}
+
+// probeContentSize 用一个限时很短的小范围请求（Range: bytes=0-0）探测文件
+// 总大小，超时独立于上层 ctx，避免一次探测拖慢整条读取链路。
+func (handler *Driver) probeContentSize(ctx context.Context, contentURL string) (int64, error) {
+	probeCtx, cancel := context.WithTimeout(ctx, contentProbeTimeout)
+	defer cancel()
+
+	resp := handler.Client.Request(http.MethodGet, contentURL, nil,
+		request.WithContext(probeCtx),
+		request.WithHeader(http.Header{"Range": {"bytes=0-0"}}),
+	)
+	if resp.Err != nil {
+		return 0, fmt.Errorf("remote: failed to probe content size: %w", resp.Err)
+	}
+	defer resp.Response.Body.Close()
+
+	if size, ok := parseContentRangeSize(resp.Response.Header.Get("Content-Range")); ok {
+		return size, nil
+	}
+
+	if cl := resp.Response.Header.Get("Content-Length"); cl != "" {
+		if size, err := strconv.ParseInt(cl, 10, 64); err == nil {
+			return size, nil
+		}
+	}
+
+	return 0, fmt.Errorf("remote: slave node did not report content size")
+}
+
+// parseContentRangeSize 从形如 "bytes 0-0/12345" 的 Content-Range 响应头中
+// 解析出文件总大小。
+func parseContentRangeSize(contentRange string) (int64, bool) {
+	idx := strings.LastIndex(contentRange, "/")
+	if idx < 0 || idx == len(contentRange)-1 {
+		return 0, false
+	}
+
+	size, err := strconv.ParseInt(contentRange[idx+1:], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return size, true
+}
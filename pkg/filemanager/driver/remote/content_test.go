@@ -0,0 +1,183 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+// fakeRangeBody 是一个可控的 io.ReadCloser，用来模拟"读到一半连接中断"。
+type fakeRangeBody struct {
+	data    []byte
+	pos     int
+	breakAt int // 读到第几个字节后返回错误，-1 表示不中断
+	err     error
+	closed  bool
+}
+
+func (b *fakeRangeBody) Read(p []byte) (int, error) {
+	if b.breakAt >= 0 && b.pos >= b.breakAt {
+		return 0, b.err
+	}
+
+	end := len(b.data)
+	if b.breakAt >= 0 && b.breakAt < end {
+		end = b.breakAt
+	}
+
+	n := copy(p, b.data[b.pos:end])
+	b.pos += n
+	if n == 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+func (b *fakeRangeBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestRemoteFileStreamReadResumesFromOffsetAfterTransientError(t *testing.T) {
+	full := []byte("hello world, this is the remote file content")
+
+	var openedAt []int64
+	opens := 0
+	opener := func(ctx context.Context, offset int64) (io.ReadCloser, int64, error) {
+		openedAt = append(openedAt, offset)
+		opens++
+		if opens == 1 {
+			// 第一次打开时连接在读了 5 个字节后中断
+			return &fakeRangeBody{data: full[offset:], breakAt: 5, err: errors.New("connection reset")}, int64(len(full)), nil
+		}
+		return &fakeRangeBody{data: full[offset:], breakAt: -1}, int64(len(full)), nil
+	}
+
+	s := &remoteFileStream{ctx: context.Background(), size: -1, openRange: opener}
+
+	got, err := io.ReadAll(s)
+	if err != nil {
+		t.Fatalf("ReadAll returned error: %v", err)
+	}
+	if string(got) != string(full) {
+		t.Fatalf("content mismatch: got %q, want %q", got, full)
+	}
+
+	if len(openedAt) != 2 {
+		t.Fatalf("expected exactly 2 range opens (initial + resume), got %d: %v", len(openedAt), openedAt)
+	}
+	if openedAt[0] != 0 {
+		t.Fatalf("expected first open at offset 0, got %d", openedAt[0])
+	}
+	if openedAt[1] != 5 {
+		t.Fatalf("expected resume to re-open at offset 5 (already-read bytes), got %d", openedAt[1])
+	}
+}
+
+func TestRemoteFileStreamReadFailsAfterExhaustingRetries(t *testing.T) {
+	opener := func(ctx context.Context, offset int64) (io.ReadCloser, int64, error) {
+		return nil, -1, errors.New("node unreachable")
+	}
+
+	s := &remoteFileStream{ctx: context.Background(), size: -1, openRange: opener}
+
+	_, err := s.Read(make([]byte, 4))
+	if err == nil {
+		t.Fatal("expected Read to fail after exhausting retries, got nil error")
+	}
+}
+
+func TestRemoteFileStreamSeekDoesNotReopenForSamePosition(t *testing.T) {
+	full := []byte("0123456789")
+	opens := 0
+	opener := func(ctx context.Context, offset int64) (io.ReadCloser, int64, error) {
+		opens++
+		return &fakeRangeBody{data: full[offset:], breakAt: -1}, int64(len(full)), nil
+	}
+
+	s := &remoteFileStream{ctx: context.Background(), size: -1, openRange: opener}
+
+	buf := make([]byte, 3)
+	if _, err := s.Read(buf); err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if opens != 1 {
+		t.Fatalf("expected 1 open after first read, got %d", opens)
+	}
+
+	// Seek 到当前读取位置（无跳转）不应使已打开的连接失效
+	if _, err := s.Seek(3, io.SeekStart); err != nil {
+		t.Fatalf("Seek failed: %v", err)
+	}
+	if _, err := s.Read(buf); err != nil {
+		t.Fatalf("Read after no-op seek failed: %v", err)
+	}
+	if opens != 1 {
+		t.Fatalf("expected seek to same position to reuse the open connection, got %d opens", opens)
+	}
+
+	// 跳转到不同位置必须在下一次 Read 时重新发起 Range 请求
+	if _, err := s.Seek(7, io.SeekStart); err != nil {
+		t.Fatalf("Seek failed: %v", err)
+	}
+	var got bytes.Buffer
+	if _, err := io.Copy(&got, s); err != nil {
+		t.Fatalf("Read after seek failed: %v", err)
+	}
+	if opens != 2 {
+		t.Fatalf("expected seek to a new position to re-open at the new offset, got %d opens", opens)
+	}
+	if got.String() != "789" {
+		t.Fatalf("unexpected content after seek: got %q, want %q", got.String(), "789")
+	}
+}
+
+func TestRemoteFileStreamSeekEndProbesSizeOnce(t *testing.T) {
+	probes := 0
+	s := &remoteFileStream{
+		ctx:  context.Background(),
+		size: -1,
+		probeSizeFn: func(ctx context.Context) (int64, error) {
+			probes++
+			return 100, nil
+		},
+	}
+
+	pos, err := s.Seek(-10, io.SeekEnd)
+	if err != nil {
+		t.Fatalf("Seek failed: %v", err)
+	}
+	if pos != 90 {
+		t.Fatalf("expected seek position 90, got %d", pos)
+	}
+
+	if _, err := s.Seek(-1, io.SeekEnd); err != nil {
+		t.Fatalf("second SeekEnd failed: %v", err)
+	}
+	if probes != 1 {
+		t.Fatalf("expected probed size to be cached across SeekEnd calls, got %d probes", probes)
+	}
+}
+
+func TestParseContentRangeSize(t *testing.T) {
+	cases := []struct {
+		header   string
+		wantSize int64
+		wantOk   bool
+	}{
+		{"bytes 0-0/12345", 12345, true},
+		{"bytes 100-199/500", 500, true},
+		{"", 0, false},
+		{"bytes */*", 0, false},
+		{"not-a-content-range", 0, false},
+	}
+
+	for _, c := range cases {
+		size, ok := parseContentRangeSize(c.header)
+		if ok != c.wantOk || (ok && size != c.wantSize) {
+			t.Errorf("parseContentRangeSize(%q) = (%d, %v), want (%d, %v)", c.header, size, ok, c.wantSize, c.wantOk)
+		}
+	}
+}
@@ -2,10 +2,9 @@ package remote
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
-	"os"
 	"time"
 
 	"github.com/cloudreve/Cloudreve/v4/ent"
@@ -34,6 +33,10 @@ type Driver struct {
 	uploadClient Client
 	config       conf.ConfigProvider
 	settings     setting.Provider
+	logger       logging.Logger
+
+	stats      statsCache
+	statsProbe statsProbeFunc
 }
 
 // New initializes a new Driver from policy
@@ -44,14 +47,18 @@ func New(ctx context.Context, policy *ent.StoragePolicy, settings setting.Provid
 		return nil, err
 	}
 
-	return &Driver{
+	handler := &Driver{
 		Policy:       policy,
 		Client:       request.NewClient(config),
 		AuthInstance: auth.HMACAuth{[]byte(policy.Edges.Node.SlaveKey)},
 		uploadClient: client,
 		settings:     settings,
 		config:       config,
-	}, nil
+		logger:       l,
+	}
+	handler.statsProbe = handler.probeNodeStats
+
+	return handler, nil
 }
 
 // List 列取文件
@@ -65,11 +72,6 @@ func (handler *Driver) List(ctx context.Context, base string, onProgress driver.
 	return res, nil
 }
 
-// Open 获取文件内容
-func (handler *Driver) Open(ctx context.Context, path string) (*os.File, error) {
-	return nil, errors.New("not implemented")
-}
-
 func (handler *Driver) LocalPath(ctx context.Context, path string) string {
 	return ""
 }
@@ -144,16 +146,17 @@ func (handler *Driver) Token(ctx context.Context, uploadSession *fs.UploadSessio
 		return nil, err
 	}
 
-	// 获取上传地址
-	uploadURL, sign, err := handler.uploadClient.GetUploadURL(ctx, uploadSession.Props.ExpireAt, uploadSession.Props.UploadSessionID)
+	// 按分片大小预签一组上传地址，单个分片大小的文件仍只签发一个地址
+	chunkSize := handler.Policy.Settings.ChunkSize
+	uploadURLs, sign, err := handler.chunkUploadURLs(ctx, uploadSession, file, chunkSize)
 	if err != nil {
-		return nil, fmt.Errorf("failed to sign upload url: %w", err)
+		return nil, err
 	}
 
 	return &fs.UploadCredential{
 		SessionID:  uploadSession.Props.UploadSessionID,
-		ChunkSize:  handler.Policy.Settings.ChunkSize,
-		UploadURLs: []string{uploadURL},
+		ChunkSize:  chunkSize,
+		UploadURLs: uploadURLs,
 		Credential: sign,
 	}, nil
 }
@@ -163,10 +166,47 @@ func (handler *Driver) CancelToken(ctx context.Context, uploadSession *fs.Upload
 	return handler.uploadClient.DeleteUploadSession(ctx, uploadSession.Props.UploadSessionID)
 }
 
+// CompleteUpload 通知从机把该会话已上传的全部分片按序拼装为最终文件。
+// 文件大小未超过单个分片、从未走过分片上传路径的会话在从机侧是 no-op。
+// 与 Thumb/Source 一样，通过签名地址直接向从机发起 HTTP 请求，而不是依赖
+// uploadClient 上一个并不存在的 RPC 方法。
 func (handler *Driver) CompleteUpload(ctx context.Context, session *fs.UploadSession) error {
+	completeURL, err := handler.signedCompleteUploadURL(ctx, session.Props.UploadSessionID)
+	if err != nil {
+		return fmt.Errorf("remote: failed to complete upload session %q: %w", session.Props.UploadSessionID, err)
+	}
+
+	resp := handler.Client.Request(http.MethodPost, completeURL, nil, request.WithContext(ctx))
+	if resp.Err != nil {
+		return fmt.Errorf("remote: failed to complete upload session %q: %w", session.Props.UploadSessionID, resp.Err)
+	}
+	defer resp.Response.Body.Close()
+
+	if resp.Response.StatusCode != http.StatusOK {
+		return fmt.Errorf("remote: slave node rejected complete request for session %q with status %d",
+			session.Props.UploadSessionID, resp.Response.StatusCode)
+	}
+
 	return nil
 }
 
+// signedCompleteUploadURL 生成带签名且限时有效的从机分片拼装地址。
+func (handler *Driver) signedCompleteUploadURL(ctx context.Context, sessionID string) (string, error) {
+	server, err := url.Parse(handler.Policy.Edges.Node.Server)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse node server url: %w", err)
+	}
+
+	base := routes.SlaveCompleteChunkUploadUrl(server, sessionID)
+	expire := time.Now().Add(contentURLExpire)
+	signed, err := auth.SignURI(ctx, handler.AuthInstance, base.String(), &expire)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign complete upload url: %w", err)
+	}
+
+	return signed.String(), nil
+}
+
 func (handler *Driver) Capabilities() *driver.Capabilities {
 	return &driver.Capabilities{
 		StaticFeatures:         features,
@@ -0,0 +1,151 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/cloudreve/Cloudreve/v4/pkg/auth"
+	"github.com/cloudreve/Cloudreve/v4/pkg/cluster/routes"
+	"github.com/cloudreve/Cloudreve/v4/pkg/metrics"
+	"github.com/cloudreve/Cloudreve/v4/pkg/request"
+)
+
+const (
+	// statsCacheTTL 探测结果的缓存有效期，过期后下一次 Stats 调用会
+	// 同步重新探测一次
+	statsCacheTTL = 30 * time.Second
+	// statsProbeTimeout 单次探测请求的超时时间，独立于调用方传入的 ctx，
+	// 避免一个离线/无响应的从机让探测请求无限挂起——Stats 的互斥锁在探测
+	// 期间是持有状态，挂起的探测会连带卡住同一 Driver 上所有并发调用者
+	statsProbeTimeout = 5 * time.Second
+	// statsURLExpire 健康探测地址签名的有效期
+	statsURLExpire = 5 * time.Minute
+)
+
+// NodeStats 描述从机节点的健康状况与容量信息，供调度器在多个从机间
+// 择优使用。
+type NodeStats struct {
+	FreeBytes       uint64
+	UsedBytes       uint64
+	CPUUsage        float64
+	InflightUploads int
+	Latency         time.Duration
+	Offline         bool
+	ProbedAt        time.Time
+}
+
+// statsProbeFunc 发起一次对从机健康状况的探测。抽成函数类型而非直接依赖
+// *Driver，便于单测在不搭建真实 HTTP 客户端的情况下注入可控的探测结果，
+// 与 content.go 的 rangeOpenFunc 是同一种做法。
+type statsProbeFunc func(ctx context.Context) (*NodeStats, error)
+
+// statsCache 并发安全地缓存最近一次探测到的 NodeStats，互斥锁同时
+// 避免缓存过期时多个并发调用同时触发探测。
+type statsCache struct {
+	mu    sync.Mutex
+	value *NodeStats
+}
+
+// fresh 判断缓存的探测结果相对 now 是否仍在 statsCacheTTL 有效期内。
+// 调用方需持有 mu。
+func (c *statsCache) fresh(now time.Time) bool {
+	return c.value != nil && now.Sub(c.value.ProbedAt) <= statsCacheTTL
+}
+
+// Stats 返回从机的健康状况，缓存有效期内直接复用上一次的探测结果；
+// 过期后同步探测一次再返回，探测失败时把节点标记为离线而不是报错，
+// 方便调用方直接据此跳过该节点。Driver 按策略/按操作创建，这里不使用
+// 后台协程轮询，避免每次创建 Driver 都产生一个无人负责关闭的探测循环。
+func (handler *Driver) Stats(ctx context.Context) *NodeStats {
+	handler.stats.mu.Lock()
+	defer handler.stats.mu.Unlock()
+
+	if handler.stats.fresh(time.Now()) {
+		return handler.stats.value
+	}
+
+	return handler.refreshStats(ctx, handler.Policy.Edges.Node.Name)
+}
+
+// refreshStats 实际发起一次探测并刷新缓存，独立于 Stats 是为了让 nodeName
+// 可以在单测里直接传入，不必构造一个完整的 Policy/Node 才能驱动这段缓存
+// 刷新/降级为离线的逻辑。调用方需持有 handler.stats.mu。
+func (handler *Driver) refreshStats(ctx context.Context, nodeName string) *NodeStats {
+	probeCtx, cancel := context.WithTimeout(ctx, statsProbeTimeout)
+	start := time.Now()
+	stats, err := handler.statsProbe(probeCtx)
+	latency := time.Since(start)
+	cancel()
+
+	if err != nil {
+		if handler.logger != nil {
+			handler.logger.Warning("Failed to probe remote node %q stats: %s", nodeName, err)
+		}
+
+		stats = &NodeStats{Offline: true}
+		metrics.RemoteNodeOffline.WithLabelValues(nodeName).Set(1)
+	} else {
+		metrics.RemoteNodeOffline.WithLabelValues(nodeName).Set(0)
+		metrics.RemoteNodeFreeBytes.WithLabelValues(nodeName).Set(float64(stats.FreeBytes))
+		metrics.RemoteNodeLatencySeconds.WithLabelValues(nodeName).Observe(latency.Seconds())
+	}
+
+	stats.Latency = latency
+	stats.ProbedAt = time.Now()
+	handler.stats.value = stats
+
+	return stats
+}
+
+// nodeStatusReport 镜像 pkg/cluster/slave.NodeStatusReport 的 JSON 结构，
+// 不直接依赖 slave 包——remote 是主机侧代码，slave 是从机侧代码，两者只
+// 通过 HTTP + JSON 这一层协议耦合，不应该产生 Go 包依赖。
+type nodeStatusReport struct {
+	FreeBytes       uint64  `json:"free_bytes"`
+	UsedBytes       uint64  `json:"used_bytes"`
+	CPUUsage        float64 `json:"cpu_usage"`
+	InflightUploads int     `json:"inflight_uploads"`
+}
+
+// probeNodeStats 向从机的 GET /slave/status 发起一次签名请求，解析出节点
+// 当前的容量与负载状况。
+func (handler *Driver) probeNodeStats(ctx context.Context) (*NodeStats, error) {
+	server, err := url.Parse(handler.Policy.Edges.Node.Server)
+	if err != nil {
+		return nil, fmt.Errorf("remote: failed to parse node server url: %w", err)
+	}
+
+	base := routes.SlaveStatusUrl(server)
+	expire := time.Now().Add(statsURLExpire)
+	signed, err := auth.SignURI(ctx, handler.AuthInstance, base.String(), &expire)
+	if err != nil {
+		return nil, fmt.Errorf("remote: failed to sign status url: %w", err)
+	}
+
+	resp := handler.Client.Request(http.MethodGet, signed.String(), nil, request.WithContext(ctx))
+	if resp.Err != nil {
+		return nil, fmt.Errorf("remote: status request failed: %w", resp.Err)
+	}
+	defer resp.Response.Body.Close()
+
+	if resp.Response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("remote: slave node returned status %d for health probe", resp.Response.StatusCode)
+	}
+
+	var report nodeStatusReport
+	if err := json.NewDecoder(resp.Response.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("remote: failed to decode status response: %w", err)
+	}
+
+	return &NodeStats{
+		FreeBytes:       report.FreeBytes,
+		UsedBytes:       report.UsedBytes,
+		CPUUsage:        report.CPUUsage,
+		InflightUploads: report.InflightUploads,
+	}, nil
+}
@@ -0,0 +1,111 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStatsCacheFresh(t *testing.T) {
+	now := time.Now()
+
+	cases := []struct {
+		name      string
+		cache     statsCache
+		at        time.Time
+		wantFresh bool
+	}{
+		{
+			name:      "empty cache is never fresh",
+			cache:     statsCache{},
+			at:        now,
+			wantFresh: false,
+		},
+		{
+			name:      "just under TTL is fresh",
+			cache:     statsCache{value: &NodeStats{ProbedAt: now.Add(-statsCacheTTL + time.Second)}},
+			at:        now,
+			wantFresh: true,
+		},
+		{
+			name:      "exactly at TTL boundary is still fresh",
+			cache:     statsCache{value: &NodeStats{ProbedAt: now.Add(-statsCacheTTL)}},
+			at:        now,
+			wantFresh: true,
+		},
+		{
+			name:      "just over TTL is stale",
+			cache:     statsCache{value: &NodeStats{ProbedAt: now.Add(-statsCacheTTL - time.Second)}},
+			at:        now,
+			wantFresh: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.cache.fresh(c.at); got != c.wantFresh {
+				t.Errorf("fresh() = %v, want %v", got, c.wantFresh)
+			}
+		})
+	}
+}
+
+// TestStatsUsesCacheWithoutReprobing 证明缓存命中时 Stats 不会调用
+// statsProbe，避免每次调用都重新探测从机。
+func TestStatsUsesCacheWithoutReprobing(t *testing.T) {
+	handler := &Driver{}
+	calls := 0
+	handler.statsProbe = func(ctx context.Context) (*NodeStats, error) {
+		calls++
+		return &NodeStats{FreeBytes: 1}, nil
+	}
+	handler.stats.value = &NodeStats{FreeBytes: 42, ProbedAt: time.Now()}
+
+	got := handler.Stats(context.Background())
+	if got.FreeBytes != 42 {
+		t.Fatalf("FreeBytes = %d, want 42 (expected cached value)", got.FreeBytes)
+	}
+	if calls != 0 {
+		t.Fatalf("statsProbe called %d times, want 0", calls)
+	}
+}
+
+// TestRefreshStatsReturnsProbedValueAndCachesIt 证明过期后的探测结果既会
+// 被返回，也会写回缓存供下一次调用复用。
+func TestRefreshStatsReturnsProbedValueAndCachesIt(t *testing.T) {
+	handler := &Driver{}
+	handler.statsProbe = func(ctx context.Context) (*NodeStats, error) {
+		return &NodeStats{FreeBytes: 7}, nil
+	}
+
+	got := handler.refreshStats(context.Background(), "test-node")
+	if got.FreeBytes != 7 {
+		t.Fatalf("FreeBytes = %d, want 7", got.FreeBytes)
+	}
+	if handler.stats.value != got {
+		t.Fatal("expected refreshStats to write the probed result back into the cache")
+	}
+	if got.ProbedAt.IsZero() {
+		t.Fatal("expected ProbedAt to be stamped on the probed result")
+	}
+}
+
+// TestRefreshStatsMarksNodeOfflineOnProbeError 证明探测失败时节点被标记为
+// 离线，而不是把错误一路往上抛给调用方——调用方应该能直接跳过离线节点。
+func TestRefreshStatsMarksNodeOfflineOnProbeError(t *testing.T) {
+	handler := &Driver{}
+	calls := 0
+	handler.statsProbe = func(ctx context.Context) (*NodeStats, error) {
+		calls++
+		return nil, fmt.Errorf("node unreachable")
+	}
+
+	got := handler.refreshStats(context.Background(), "test-node")
+	if calls != 1 {
+		t.Fatalf("statsProbe called %d times, want 1", calls)
+	}
+	if !got.Offline {
+		t.Fatal("expected node to be marked offline after a failed probe")
+	}
+}
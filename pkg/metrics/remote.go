@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// RemoteNodeOffline 标记从机节点在最近一次探测中是否离线，1 为离线，0 为在线
+	RemoteNodeOffline = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cloudreve",
+		Subsystem: "remote_node",
+		Name:      "offline",
+		Help:      "Whether the remote storage node was offline on the last health probe.",
+	}, []string{"node"})
+
+	// RemoteNodeFreeBytes 从机节点最近一次探测到的可用存储空间（字节）
+	RemoteNodeFreeBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cloudreve",
+		Subsystem: "remote_node",
+		Name:      "free_bytes",
+		Help:      "Free storage space on the remote node as of the last health probe, in bytes.",
+	}, []string{"node"})
+
+	// RemoteNodeLatencySeconds 探测从机节点健康状况接口的往返延迟
+	RemoteNodeLatencySeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "cloudreve",
+		Subsystem: "remote_node",
+		Name:      "probe_latency_seconds",
+		Help:      "Round-trip latency of the remote node health probe.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"node"})
+)